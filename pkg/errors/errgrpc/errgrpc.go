@@ -0,0 +1,88 @@
+// Package errgrpc mirrors errhttp for gRPC: a code mapping plus unary/stream
+// interceptors that recover panics and translate sentinel errors into a
+// grpc/status error with the right code.
+package errgrpc
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/exactlylabs/go-errors/pkg/errors"
+	"github.com/exactlylabs/go-errors/pkg/errors/errlog"
+)
+
+// CodeMapper maps sentinel errors to the grpc status code interceptors
+// should return when errors.Is(err, sentinel) is true. Mappings are checked
+// in registration order, so register more specific sentinels first.
+type CodeMapper struct {
+	mu       sync.RWMutex
+	mappings []codeMapping
+}
+
+type codeMapping struct {
+	sentinel error
+	code     codes.Code
+}
+
+func NewCodeMapper() *CodeMapper {
+	return &CodeMapper{}
+}
+
+// Register maps sentinel to code.
+func (m *CodeMapper) Register(sentinel error, code codes.Code) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mappings = append(m.mappings, codeMapping{sentinel, code})
+}
+
+// CodeFor returns the code registered for the first sentinel matching err,
+// or codes.Internal if none match.
+func (m *CodeMapper) CodeFor(err error) codes.Code {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, mapping := range m.mappings {
+		if errors.Is(err, mapping.sentinel) {
+			return mapping.code
+		}
+	}
+	return codes.Internal
+}
+
+// UnaryServerInterceptor recovers panics via errors.RecoverPanic, logs the
+// resulting error to logger with its stacktrace and metadata attached (via
+// errlog.ToSlogAttrs), and maps it to a grpc status built from mapper.
+func UnaryServerInterceptor(mapper *CodeMapper, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			errors.RecoverPanic(recover(), &err)
+			if err != nil {
+				logger.LogAttrs(ctx, slog.LevelError, "rpc failed", errlog.ToSlogAttrs(err)...)
+				err = toStatus(mapper, err)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs.
+func StreamServerInterceptor(mapper *CodeMapper, logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			errors.RecoverPanic(recover(), &err)
+			if err != nil {
+				logger.LogAttrs(ss.Context(), slog.LevelError, "rpc failed", errlog.ToSlogAttrs(err)...)
+				err = toStatus(mapper, err)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func toStatus(mapper *CodeMapper, err error) error {
+	return status.Error(mapper.CodeFor(err), err.Error())
+}
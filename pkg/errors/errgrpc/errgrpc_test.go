@@ -0,0 +1,92 @@
+package errgrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/exactlylabs/go-errors/pkg/errors"
+)
+
+var errNotFound = errors.NewSentinel("NotFoundError", "not found")
+
+func TestUnaryServerInterceptorMapsCodeAndLogs(t *testing.T) {
+	mapper := NewCodeMapper()
+	mapper.Register(errNotFound, codes.NotFound)
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	interceptor := UnaryServerInterceptor(mapper, logger)
+	handler := func(ctx context.Context, req any) (any, error) {
+		panic(errors.SentinelWithStack(errNotFound).WithMetadata(errors.Metadata{"ID": 7}))
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	if err == nil {
+		t.Fatalf("interceptor returned nil error, want the mapped status error")
+	}
+	if code := status.Code(err); code != codes.NotFound {
+		t.Fatalf("status.Code(err) = %v, want %v", code, codes.NotFound)
+	}
+
+	if logs.Len() == 0 {
+		t.Fatalf("UnaryServerInterceptor did not log the recovered error")
+	}
+	var record map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("failed to unmarshal log record: %v", err)
+	}
+	if typeStr, ok := record["error.type"].(string); !ok || !strings.Contains(typeStr, "NotFoundError") {
+		t.Fatalf("logged error.type = %v, want it to mention \"NotFoundError\"", record["error.type"])
+	}
+	if _, ok := record["ID"]; !ok {
+		t.Fatalf("logged record missing metadata key \"ID\": %v", record)
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptorMapsCodeAndLogs(t *testing.T) {
+	mapper := NewCodeMapper()
+	mapper.Register(errNotFound, codes.NotFound)
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	interceptor := StreamServerInterceptor(mapper, logger)
+	handler := func(srv any, ss grpc.ServerStream) error {
+		panic(errors.SentinelWithStack(errNotFound).WithMetadata(errors.Metadata{"ID": 7}))
+	}
+
+	err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, handler)
+	if err == nil {
+		t.Fatalf("interceptor returned nil error, want the mapped status error")
+	}
+	if code := status.Code(err); code != codes.NotFound {
+		t.Fatalf("status.Code(err) = %v, want %v", code, codes.NotFound)
+	}
+
+	if logs.Len() == 0 {
+		t.Fatalf("StreamServerInterceptor did not log the recovered error")
+	}
+	var record map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("failed to unmarshal log record: %v", err)
+	}
+	if typeStr, ok := record["error.type"].(string); !ok || !strings.Contains(typeStr, "NotFoundError") {
+		t.Fatalf("logged error.type = %v, want it to mention \"NotFoundError\"", record["error.type"])
+	}
+}
@@ -0,0 +1,95 @@
+// Package errhttp turns the sentinel-with-typeStr design in
+// pkg/errors.NewSentinel/SentinelWithStack into a transport-layer contract: a
+// status-code mapping plus middleware that recovers panics and renders a
+// consistent JSON error body.
+package errhttp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/exactlylabs/go-errors/pkg/errors"
+	"github.com/exactlylabs/go-errors/pkg/errors/errlog"
+)
+
+// StatusMapper maps sentinel errors to the HTTP status code Middleware
+// should respond with when errors.Is(err, sentinel) is true. Mappings are
+// checked in registration order, so register more specific sentinels first.
+type StatusMapper struct {
+	mu       sync.RWMutex
+	mappings []statusMapping
+}
+
+type statusMapping struct {
+	sentinel error
+	status   int
+}
+
+func NewStatusMapper() *StatusMapper {
+	return &StatusMapper{}
+}
+
+// Register maps sentinel to status.
+func (m *StatusMapper) Register(sentinel error, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mappings = append(m.mappings, statusMapping{sentinel, status})
+}
+
+// StatusFor returns the status registered for the first sentinel matching
+// err, or http.StatusInternalServerError if none match.
+func (m *StatusMapper) StatusFor(err error) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, mapping := range m.mappings {
+		if errors.Is(err, mapping.sentinel) {
+			return mapping.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// errorBody is the JSON shape written for any error Middleware catches.
+type errorBody struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// Middleware recovers panics via errors.RecoverPanic, maps the resulting
+// error to a status code via mapper, logs it to logger with its stacktrace
+// and metadata attached (via errlog.ToSlogAttrs), and writes
+// {"type": typeStr, "message": ..., "trace_id": ...} instead of letting the
+// panic reach net/http's default recovery behavior. The trace ID, if any, is
+// read from the X-Trace-Id request header.
+func Middleware(mapper *StatusMapper, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				var err error
+				errors.RecoverPanic(recover(), &err)
+				if err != nil {
+					logger.LogAttrs(r.Context(), slog.LevelError, "request failed", errlog.ToSlogAttrs(err)...)
+					writeError(w, r, mapper, err)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, mapper *StatusMapper, err error) {
+	typeStr := ""
+	if t, ok := err.(interface{ Type() string }); ok {
+		typeStr = t.Type()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(mapper.StatusFor(err))
+	_ = json.NewEncoder(w).Encode(errorBody{
+		Type:    typeStr,
+		Message: err.Error(),
+		TraceID: r.Header.Get("X-Trace-Id"),
+	})
+}
@@ -0,0 +1,57 @@
+package errhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/exactlylabs/go-errors/pkg/errors"
+)
+
+var errNotFound = errors.NewSentinel("NotFoundError", "not found")
+
+func TestMiddlewareMapsStatusAndLogs(t *testing.T) {
+	mapper := NewStatusMapper()
+	mapper.Register(errNotFound, http.StatusNotFound)
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	handler := Middleware(mapper, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(errors.SentinelWithStack(errNotFound).WithMetadata(errors.Metadata{"ID": 7}))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/things/7", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal response body: %v", err)
+	}
+	if body["type"] != "NotFoundError" {
+		t.Fatalf("body[\"type\"] = %v, want \"NotFoundError\"", body["type"])
+	}
+
+	if logs.Len() == 0 {
+		t.Fatalf("Middleware did not log the recovered error")
+	}
+	var record map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("failed to unmarshal log record: %v", err)
+	}
+	if typeStr, ok := record["error.type"].(string); !ok || !strings.Contains(typeStr, "NotFoundError") {
+		t.Fatalf("logged error.type = %v, want it to mention \"NotFoundError\"", record["error.type"])
+	}
+	if _, ok := record["ID"]; !ok {
+		t.Fatalf("logged record missing metadata key \"ID\": %v", record)
+	}
+}
@@ -0,0 +1,135 @@
+// Package errlog adapts an error built with pkg/errors into the structured
+// fields slog, zap and logrus expect, so a single call gives a logger the
+// error's type chain, message, stacktrace and metadata without the caller
+// having to reach into errors.GetMetadata/StackTrace by hand.
+package errlog
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+
+	"github.com/exactlylabs/go-errors/pkg/errors"
+)
+
+// maxFrames caps how many stack frames are emitted under error.stack, since
+// most logging backends truncate or charge per-field on long values.
+const maxFrames = 10
+
+// ToSlogAttrs returns the attributes describing err: error.type, error.message,
+// error.stack, and one attribute per metadata key, flattened with dotted
+// paths for nested errors.Metadata values (e.g. "DeepStruct.Nested").
+func ToSlogAttrs(err error) []slog.Attr {
+	fs := fields(err)
+	attrs := make([]slog.Attr, 0, len(fs))
+	for _, f := range fs {
+		attrs = append(attrs, slog.Any(f.key, f.value))
+	}
+	return attrs
+}
+
+// ToZapFields is ToSlogAttrs for zap.
+func ToZapFields(err error) []zap.Field {
+	fs := fields(err)
+	zf := make([]zap.Field, 0, len(fs))
+	for _, f := range fs {
+		zf = append(zf, zap.Any(f.key, f.value))
+	}
+	return zf
+}
+
+// ToLogrusFields is ToSlogAttrs for logrus.
+func ToLogrusFields(err error) logrus.Fields {
+	fs := fields(err)
+	lf := make(logrus.Fields, len(fs))
+	for _, f := range fs {
+		lf[f.key] = f.value
+	}
+	return lf
+}
+
+type field struct {
+	key   string
+	value any
+}
+
+func fields(err error) []field {
+	if err == nil {
+		return nil
+	}
+	fs := []field{
+		{"error.type", typeChain(err)},
+		{"error.message", err.Error()},
+	}
+	if stack := stackOf(err); len(stack) > 0 {
+		fs = append(fs, field{"error.stack", stackFrames(stack)})
+	}
+	// WalkMetadata merges metadata across the whole chain (outer overrides
+	// inner on conflict) instead of just the outermost node, so causes added
+	// via errors.Join/WrapMulti -- which each get their own metadata map --
+	// aren't silently dropped.
+	errors.WalkMetadata(err, func(path []string, v any) {
+		fs = append(fs, field{strings.Join(path, "."), v})
+	})
+	return fs
+}
+
+// typeChain joins the Type() of every error in err's chain that has one,
+// outermost first, e.g. "ErrInvalidError < LibraryBaseError".
+func typeChain(err error) string {
+	var types []string
+	for _, e := range chain(err) {
+		if t, ok := e.(interface{ Type() string }); ok {
+			if typeStr := t.Type(); typeStr != "" {
+				types = append(types, typeStr)
+			}
+		}
+	}
+	return strings.Join(types, " < ")
+}
+
+// stackOf returns the first non-empty stacktrace found walking err's chain
+// outermost first, i.e. the stacktrace of the outermost error that has one,
+// the same node whose top frame Error() reports.
+func stackOf(err error) errors.StackTrace {
+	for _, e := range chain(err) {
+		if st, ok := e.(interface{ StackTrace() errors.StackTrace }); ok {
+			if stack := st.StackTrace(); len(stack) > 0 {
+				return stack
+			}
+		}
+	}
+	return nil
+}
+
+func stackFrames(stack errors.StackTrace) []string {
+	if len(stack) > maxFrames {
+		stack = stack[:maxFrames]
+	}
+	frames := make([]string, 0, len(stack))
+	for _, f := range stack {
+		frames = append(frames, fmt.Sprintf("%s.%s (%s:%d)", f.Package(), f.FuncName(), f.File, f.Line))
+	}
+	return frames
+}
+
+// chain walks err depth-first over Unwrap() []error / Unwrap() error,
+// returning err followed by every cause in the tree.
+func chain(err error) []error {
+	if err == nil {
+		return nil
+	}
+	errs := []error{err}
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, c := range x.Unwrap() {
+			errs = append(errs, chain(c)...)
+		}
+	case interface{ Unwrap() error }:
+		errs = append(errs, chain(x.Unwrap())...)
+	}
+	return errs
+}
@@ -0,0 +1,29 @@
+package errlog
+
+import (
+	"testing"
+
+	"github.com/exactlylabs/go-errors/pkg/errors"
+)
+
+func TestToSlogAttrsMergesMetadataAcrossJoinedCauses(t *testing.T) {
+	e1 := errors.New("first failure").WithMetadata(errors.Metadata{"a": 1})
+	e2 := errors.New("second failure").WithMetadata(errors.Metadata{"b": 2})
+	joined := errors.Join(e1, e2)
+
+	attrs := ToSlogAttrs(joined)
+
+	got := map[string]any{}
+	for _, attr := range attrs {
+		got[attr.Key] = attr.Value.Any()
+	}
+
+	// slog normalizes int values to int64 through Value.Any(), so compare
+	// against int64 rather than the int literal stored in Metadata.
+	if got["a"] != int64(1) {
+		t.Fatalf("ToSlogAttrs missing metadata %q from a joined cause, got: %v", "a", got)
+	}
+	if got["b"] != int64(2) {
+		t.Fatalf("ToSlogAttrs missing metadata %q from a joined cause, got: %v", "b", got)
+	}
+}
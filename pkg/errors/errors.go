@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime"
+	"strings"
 
 	"errors"
 )
@@ -51,17 +52,24 @@ var (
 type StackTrace []Frame
 type Metadata map[string]interface{}
 
+// WrappedError describes an error that carries one or more causes. Unwrap
+// returns the causes to walk: an empty slice for errors built with New,
+// NewWithType or NewSentinel, a single-element slice for the common
+// W/Wrap/SentinelWithStack chains, or several for errors built with Join or
+// WrapMulti.
 type WrappedError interface {
 	error
-	Unwrap() error
+	Unwrap() []error
 }
 
 type baseError struct {
 	cause      error
+	causes     []error // extra causes beyond cause, for Join/WrapMulti
 	msg        string
 	typeStr    string
 	stacktrace StackTrace
 	metadata   Metadata
+	decoded    bool // set by network.go's Decode; scopes the typeStr-based Is override to decoded errors
 }
 
 func (e *baseError) Error() string {
@@ -85,14 +93,37 @@ func (e *baseError) Error() string {
 	if e.msg != "" {
 		msg = fmt.Sprintf("[%s] %s", msg, e.msg)
 	}
-	if e.cause != nil {
-		msg = fmt.Sprintf("%s => %s", msg, e.cause.Error())
+	switch causes := e.Unwrap(); {
+	case len(causes) == 1:
+		msg = fmt.Sprintf("%s => %s", msg, causes[0].Error())
+	case len(causes) > 1:
+		lines := make([]string, len(causes))
+		for i, c := range causes {
+			lines[i] = indentTree(c.Error())
+		}
+		msg = fmt.Sprintf("%s =>\n%s", msg, strings.Join(lines, "\n"))
 	}
 	return msg
 }
 
-func (e *baseError) Unwrap() error {
-	return e.cause
+func indentTree(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = "  " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns this error's causes: e.cause followed by any extra causes
+// added via Join/WrapMulti, skipping nils. errors.Is/errors.As walk the
+// result depth-first, as documented for any Unwrap() []error since Go 1.20.
+func (e *baseError) Unwrap() []error {
+	causes := make([]error, 0, len(e.causes)+1)
+	if e.cause != nil {
+		causes = append(causes, e.cause)
+	}
+	causes = append(causes, e.causes...)
+	return causes
 }
 
 func (e *baseError) StackTrace() StackTrace {
@@ -120,55 +151,65 @@ func (e *baseError) WithMetadata(meta Metadata) *baseError {
 	return e
 }
 
-// New creates a new error with stacktrace
+// New creates a new error with stacktrace.
+//
+// msg is formatted with fmt.Sprintf, so it often ends up holding interpolated
+// request data. SafeError/Report never include it for that reason -- pass
+// reportable context via WithMetadata(Safe(...)) instead of folding it into
+// msg if you need it to show up in a PII-safe report.
 func New(msg string, args ...any) *baseError {
 	msg = fmt.Sprintf(msg, args...)
-	return &baseError{
-		nil, msg, "", getStack(0), Metadata{},
-	}
+	return &baseError{msg: msg, stacktrace: getStack(0), metadata: Metadata{}}
 }
 
 // W wraps an error in a new error with stacktrace and propagating the metadata.
 func W(err error) *baseError {
 	var baseErr *baseError
 	if As(err, &baseErr) {
-		// propagate the typeStr up if there's no new typeStr provided
-		return &baseError{err, "", baseErr.typeStr, getStack(0), baseErr.metadata}
+		// propagate the typeStr up if there's no new typeStr provided. The metadata
+		// is cloned, not shared, so later WithMetadata/WithMetadataDeep calls on this
+		// wrapper can't reach back and mutate the cause's own metadata map.
+		return &baseError{cause: err, typeStr: baseErr.typeStr, stacktrace: getStack(0), metadata: cloneMetadata(baseErr.metadata)}
 	}
-	return &baseError{err, "", "", getStack(0), Metadata{}}
+	return &baseError{cause: err, stacktrace: getStack(0), metadata: Metadata{}}
 }
 
-// Wrap wraps the given error in a new Error with the given message, having a stacktrace and propagating metadata.
+// Wrap wraps the given error in a new Error with the given message, having a
+// stacktrace and propagating metadata.
+//
+// Like New, msg is formatted with fmt.Sprintf and is never included in
+// SafeError/Report -- use WithMetadata(Safe(...)) for context that needs to
+// reach a PII-safe report.
 func Wrap(err error, msg string, args ...any) *baseError {
 	msg = fmt.Sprintf(msg, args...)
 
 	var baseErr *baseError
 	if As(err, &baseErr) {
-		// propagate the typeStr up if there's no new typeStr provided
-		return &baseError{err, msg, baseErr.typeStr, getStack(0), baseErr.metadata}
+		// propagate the typeStr up if there's no new typeStr provided. Clone the
+		// metadata for the same reason W does: each layer must own its own map.
+		return &baseError{cause: err, msg: msg, typeStr: baseErr.typeStr, stacktrace: getStack(0), metadata: cloneMetadata(baseErr.metadata)}
 	}
-	return &baseError{err, msg, "", getStack(0), Metadata{}}
+	return &baseError{cause: err, msg: msg, stacktrace: getStack(0), metadata: Metadata{}}
 }
 
 // NewWithType creates a new error with stacktrace and a custom type string returned by its Type() method
 func NewWithType(msg, typeStr string, args ...any) *baseError {
 	msg = fmt.Sprintf(msg, args...)
-	return &baseError{nil, msg, typeStr, getStack(0), Metadata{}}
+	return &baseError{msg: msg, typeStr: typeStr, stacktrace: getStack(0), metadata: Metadata{}}
 }
 
 // Wrap wraps the given error in a new error with stack trace and a custom type string returned by its Type() method
 func WrapWithType(err error, msg, typeStr string, args ...any) *baseError {
 	msg = fmt.Sprintf(msg, args...)
-	metaPtr := GetMetadata(err)
 	meta := Metadata{}
-	if metaPtr != nil {
-		meta = *metaPtr
+	if metaPtr := GetMetadata(err); metaPtr != nil {
+		meta = cloneMetadata(*metaPtr)
 	}
-	return &baseError{err, msg, typeStr, getStack(0), meta}
+	return &baseError{cause: err, msg: msg, typeStr: typeStr, stacktrace: getStack(0), metadata: meta}
 }
 
 func NewSentinel(typeStr, msg string) *baseError {
-	return &baseError{nil, msg, typeStr, nil, Metadata{}}
+	return &baseError{msg: msg, typeStr: typeStr, metadata: Metadata{}}
 }
 
 // SentinelWithStack wraps the given sentinel error and adds a stacktrace
@@ -177,9 +218,9 @@ func SentinelWithStack(err error) *baseError {
 	var baseErr *baseError
 	if As(err, &baseErr) {
 		baseErr.stacktrace = nil // Remove the stacktrace pointing to where the sentinel gets created, as this is useless
-		return &baseError{baseErr, baseErr.msg, baseErr.typeStr, getStack(0), baseErr.metadata}
+		return &baseError{cause: baseErr, msg: baseErr.msg, typeStr: baseErr.typeStr, stacktrace: getStack(0), metadata: cloneMetadata(baseErr.metadata)}
 	}
-	return &baseError{err, "", "", getStack(0), Metadata{}}
+	return &baseError{cause: err, stacktrace: getStack(0), metadata: Metadata{}}
 }
 
 func getStack(skip int) StackTrace {
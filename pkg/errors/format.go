@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format implements fmt.Formatter. %s and %v render the same compact Error()
+// string as always, %q quotes it, and %+v produces a multi-line report: the
+// message chain, each wrapper's typeStr, the full stacktrace with file:line
+// per frame, and metadata pretty-printed, recursing into nested Metadata.
+func (e *baseError) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			io.WriteString(f, e.verboseString())
+			return
+		}
+		io.WriteString(f, e.Error())
+	case 's':
+		io.WriteString(f, e.Error())
+	case 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	}
+}
+
+func (e *baseError) verboseString() string {
+	var b strings.Builder
+	fmt.Fprintln(&b, e.Error())
+	for _, node := range e.chainNodes() {
+		switch {
+		case node.typeStr != "" && node.msg != "":
+			fmt.Fprintf(&b, "[%s] %s\n", node.typeStr, node.msg)
+		case node.typeStr != "":
+			fmt.Fprintf(&b, "[%s]\n", node.typeStr)
+		case node.msg != "":
+			fmt.Fprintf(&b, "%s\n", node.msg)
+		}
+		for _, frame := range node.stacktrace {
+			fmt.Fprintf(&b, "    %s.%s\n        %s:%d\n", frame.Package(), frame.FuncName(), frame.File, frame.Line)
+		}
+		if len(node.metadata) > 0 {
+			b.WriteString("    metadata:\n")
+			writeMetadata(&b, node.metadata, 2)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// chainNodes returns e followed by every *baseError cause in its tree,
+// depth-first, for verbose reporting. A cause that is not a *baseError is
+// skipped along with everything beneath it.
+func (e *baseError) chainNodes() []*baseError {
+	nodes := []*baseError{e}
+	for _, c := range e.Unwrap() {
+		if be, ok := c.(*baseError); ok {
+			nodes = append(nodes, be.chainNodes()...)
+		}
+	}
+	return nodes
+}
+
+func writeMetadata(b *strings.Builder, m Metadata, depth int) {
+	indent := strings.Repeat("    ", depth)
+	for _, k := range sortedKeys(m) {
+		v := m[k]
+		if nested, ok := v.(Metadata); ok {
+			fmt.Fprintf(b, "%s%s:\n", indent, k)
+			writeMetadata(b, nested, depth+1)
+			continue
+		}
+		fmt.Fprintf(b, "%s%s: %v\n", indent, k, v)
+	}
+}
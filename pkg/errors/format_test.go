@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatCompactVerbsMatchError(t *testing.T) {
+	err := New("boom")
+
+	if got, want := fmt.Sprintf("%s", err), err.Error(); got != want {
+		t.Fatalf("%%s = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%v", err), err.Error(); got != want {
+		t.Fatalf("%%v = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%q", err), fmt.Sprintf("%q", err.Error()); got != want {
+		t.Fatalf("%%q = %q, want %q", got, want)
+	}
+}
+
+func TestFormatVerbosePrintsStackAndMetadata(t *testing.T) {
+	err := NewWithType("something broke", "BoomError").WithMetadata(Metadata{
+		"DeepStruct": Metadata{"Nested": 1},
+		"User":       "alice",
+	})
+
+	verbose := fmt.Sprintf("%+v", err)
+
+	if !strings.Contains(verbose, "BoomError") {
+		t.Fatalf("%%+v should mention the typeStr, got: %q", verbose)
+	}
+	if !strings.Contains(verbose, "User: alice") {
+		t.Fatalf("%%+v should pretty-print top-level metadata, got: %q", verbose)
+	}
+	if !strings.Contains(verbose, "DeepStruct:") || !strings.Contains(verbose, "Nested: 1") {
+		t.Fatalf("%%+v should recurse into nested metadata, got: %q", verbose)
+	}
+}
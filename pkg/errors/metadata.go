@@ -0,0 +1,127 @@
+package errors
+
+// cloneMetadata deep-copies m so the result can be mutated (e.g. via
+// WithMetadata/WithMetadataDeep) without reaching back into whatever map m's
+// caller still holds a reference to. W/Wrap/WrapWithType/SentinelWithStack all
+// propagate a cause's metadata into the new wrapper through this, rather
+// than reusing the cause's map directly, so each layer owns its own map.
+func cloneMetadata(m Metadata) Metadata {
+	clone := make(Metadata, len(m))
+	for k, v := range m {
+		if nested, ok := v.(Metadata); ok {
+			clone[k] = cloneMetadata(nested)
+			continue
+		}
+		clone[k] = v
+	}
+	return clone
+}
+
+// WithMetadataDeep is like WithMetadata, but recursively merges nested
+// Metadata maps instead of overwriting them outright: if a key exists in
+// both e's current metadata and meta and both values are Metadata, the two
+// are merged (meta's keys take precedence on conflict); otherwise meta's
+// value simply replaces the existing one, the same precedence WithMetadata
+// already uses at the top level.
+func (e *baseError) WithMetadataDeep(meta Metadata) *baseError {
+	if e.metadata == nil {
+		e.metadata = Metadata{}
+	}
+	mergeMetadataInto(e.metadata, meta)
+	return e
+}
+
+// mergeMetadataInto merges src's keys into dst, recursing into nested
+// Metadata values on both sides that share a key. A nested Metadata value
+// assigned wholesale (no existing counterpart to merge into) is cloned
+// first, so dst never ends up holding a live reference into src's map -
+// callers that later mutate dst (e.g. chainMetadata's fresh map, or the
+// error's own metadata in WithMetadataDeep) can't reach back and corrupt src
+// (e.g. the meta a WithMetadataDeep caller passed in).
+func mergeMetadataInto(dst, src Metadata) {
+	for k, v := range src {
+		if existing, ok := dst[k]; ok {
+			if existingMeta, ok := existing.(Metadata); ok {
+				if newMeta, ok := v.(Metadata); ok {
+					mergeMetadataInto(existingMeta, newMeta)
+					continue
+				}
+			}
+		}
+		if nested, ok := v.(Metadata); ok {
+			dst[k] = cloneMetadata(nested)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// chainMetadata merges metadata across err's whole wrap chain, innermost
+// cause first, so that outer wrappers' keys take precedence over inner
+// ones on conflict - the same parent-overrides-child rule WithMetadata
+// already applies within a single layer.
+func chainMetadata(err error) Metadata {
+	baseErr, ok := err.(*baseError)
+	if !ok {
+		if !As(err, &baseErr) {
+			return Metadata{}
+		}
+	}
+	merged := Metadata{}
+	for _, cause := range baseErr.Unwrap() {
+		mergeMetadataInto(merged, chainMetadata(cause))
+	}
+	mergeMetadataInto(merged, baseErr.metadata)
+	return merged
+}
+
+// GetString returns the string value of key in err's merged metadata chain
+// (see WalkMetadata for the precedence rule), and whether it was present as
+// a string.
+func GetString(err error, key string) (string, bool) {
+	v, ok := chainMetadata(err)[key]
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// GetInt returns the int value of key in err's merged metadata chain, and
+// whether it was present as an int.
+func GetInt(err error, key string) (int, bool) {
+	v, ok := chainMetadata(err)[key]
+	if !ok {
+		return 0, false
+	}
+	i, ok := v.(int)
+	return i, ok
+}
+
+// GetAny returns the raw value of key in err's merged metadata chain, and
+// whether it was present at all.
+func GetAny(err error, key string) (any, bool) {
+	v, ok := chainMetadata(err)[key]
+	return v, ok
+}
+
+// WalkMetadata traverses the metadata merged across err's whole wrap chain
+// and calls fn for every leaf value, with path set to its dotted key
+// segments (e.g. []string{"DeepStruct", "Nested"}). Conflicting keys
+// between layers are resolved the same way GetString/GetInt/GetAny resolve
+// them: the wrapper closer to err wins.
+func WalkMetadata(err error, fn func(path []string, v any)) {
+	walkMetadata(nil, chainMetadata(err), fn)
+}
+
+func walkMetadata(path []string, m Metadata, fn func(path []string, v any)) {
+	for _, k := range sortedKeys(m) {
+		v := m[k]
+		p := append(append([]string{}, path...), k)
+		if nested, ok := v.(Metadata); ok {
+			walkMetadata(p, nested, fn)
+			continue
+		}
+		fn(p, v)
+	}
+}
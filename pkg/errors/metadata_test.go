@@ -0,0 +1,89 @@
+package errors
+
+import "testing"
+
+func TestWithMetadataDeepDoesNotLeakIntoInnerCause(t *testing.T) {
+	inner := New("inner failure").WithMetadata(Metadata{"User": "alice"})
+	outer := Wrap(inner, "outer failure")
+
+	outer.WithMetadataDeep(Metadata{"User": "bob"})
+
+	if got, ok := GetAny(inner, "User"); !ok || got != "alice" {
+		t.Fatalf("inner metadata User = (%v, %v), want (\"alice\", true); outer.WithMetadataDeep must not mutate the inner cause's map", got, ok)
+	}
+	if got, ok := GetAny(outer, "User"); !ok || got != "bob" {
+		t.Fatalf("outer metadata User = (%v, %v), want (\"bob\", true)", got, ok)
+	}
+}
+
+func TestWithMetadataDeepMergesNestedMaps(t *testing.T) {
+	err := New("failure").WithMetadata(Metadata{
+		"DeepStruct": Metadata{"Nested": 1, "Other": "x"},
+	})
+
+	err.WithMetadataDeep(Metadata{
+		"DeepStruct": Metadata{"Nested": 2},
+	})
+
+	if v, ok := GetAny(err, "DeepStruct"); !ok {
+		t.Fatalf("DeepStruct missing after WithMetadataDeep")
+	} else if nested, ok := v.(Metadata); !ok {
+		t.Fatalf("DeepStruct is not a Metadata map: %#v", v)
+	} else {
+		if nested["Nested"] != 2 {
+			t.Fatalf("DeepStruct.Nested = %v, want 2 (meta passed to WithMetadataDeep should win)", nested["Nested"])
+		}
+		if nested["Other"] != "x" {
+			t.Fatalf("DeepStruct.Other = %v, want \"x\" to survive the merge", nested["Other"])
+		}
+	}
+}
+
+func TestWalkMetadataOuterOverridesInner(t *testing.T) {
+	inner := New("inner failure").WithMetadata(Metadata{"User": "alice", "Test": 1})
+	outer := Wrap(inner, "outer failure").WithMetadata(Metadata{"User": "replaced"})
+
+	got := map[string]any{}
+	WalkMetadata(outer, func(path []string, v any) {
+		got[pathKey(path)] = v
+	})
+
+	if got["User"] != "replaced" {
+		t.Fatalf("WalkMetadata User = %v, want \"replaced\" (outer should win over inner)", got["User"])
+	}
+	if got["Test"] != 1 {
+		t.Fatalf("WalkMetadata Test = %v, want 1 (inner-only key should still surface)", got["Test"])
+	}
+}
+
+func TestGetAnyDoesNotReturnALiveReferenceIntoInnerCause(t *testing.T) {
+	inner := New("inner failure").WithMetadata(Metadata{"D": Metadata{"N": 1}})
+	outer := Wrap(inner, "outer failure")
+
+	v, ok := GetAny(outer, "D")
+	if !ok {
+		t.Fatalf("GetAny(outer, \"D\") missing, want present")
+	}
+	nested, ok := v.(Metadata)
+	if !ok {
+		t.Fatalf("GetAny(outer, \"D\") = %#v, want a Metadata map", v)
+	}
+	nested["N"] = 999
+
+	if got, ok := GetAny(inner, "D"); !ok {
+		t.Fatalf("inner metadata D missing after mutating the map GetAny returned")
+	} else if got.(Metadata)["N"] != 1 {
+		t.Fatalf("inner metadata D.N = %v, want 1; GetAny must hand out a copy, not inner's own map", got.(Metadata)["N"])
+	}
+}
+
+func pathKey(path []string) string {
+	key := ""
+	for i, p := range path {
+		if i > 0 {
+			key += "."
+		}
+		key += p
+	}
+	return key
+}
@@ -0,0 +1,32 @@
+package errors
+
+// Join returns a new *baseError holding each non-nil error in errs as an
+// independent cause, with its own stacktrace rooted at the call to Join.
+// Error() renders the causes as an indented tree, and errors.Is/errors.As
+// walk all of them depth-first via Unwrap() []error. Join returns nil if
+// every error in errs is nil, matching the standard library's errors.Join.
+func Join(errs ...error) *baseError {
+	causes := nonNilErrors(errs)
+	if len(causes) == 0 {
+		return nil
+	}
+	return &baseError{causes: causes, stacktrace: getStack(0), metadata: Metadata{}}
+}
+
+// WrapMulti is like Join, but attaches msg as this node's own message, the
+// way Wrap attaches a message to a single cause. Unlike Join, WrapMulti
+// always returns a non-nil error, since the message itself is meaningful
+// even when every error in errs is nil.
+func WrapMulti(msg string, errs ...error) *baseError {
+	return &baseError{msg: msg, causes: nonNilErrors(errs), stacktrace: getStack(0), metadata: Metadata{}}
+}
+
+func nonNilErrors(errs []error) []error {
+	causes := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			causes = append(causes, err)
+		}
+	}
+	return causes
+}
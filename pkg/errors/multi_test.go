@@ -0,0 +1,37 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapMultiRendersIndentedTreeAndTraversesDepthFirst(t *testing.T) {
+	sentinel := NewSentinel("ValidationError", "validation failed")
+
+	e1 := Wrap(SentinelWithStack(sentinel), "field A invalid")
+	e2 := New("field B invalid")
+	joined := WrapMulti("request validation failed", e1, e2)
+
+	msg := joined.Error()
+	if !strings.Contains(msg, "field A invalid") || !strings.Contains(msg, "field B invalid") {
+		t.Fatalf("Error() did not render both causes, got: %q", msg)
+	}
+	if !strings.Contains(msg, "  ") {
+		t.Fatalf("Error() did not indent the joined causes, got: %q", msg)
+	}
+
+	if !Is(joined, sentinel) {
+		t.Fatalf("Is(joined, sentinel) = false, want true (depth-first traversal should reach e1's cause)")
+	}
+
+	var baseErr *baseError
+	if !As(joined, &baseErr) {
+		t.Fatalf("As(joined, &baseErr) = false, want true")
+	}
+}
+
+func TestJoinReturnsNilWhenEveryErrorIsNil(t *testing.T) {
+	if joined := Join(nil, nil); joined != nil {
+		t.Fatalf("Join(nil, nil) = %v, want nil", joined)
+	}
+}
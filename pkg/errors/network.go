@@ -0,0 +1,208 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// wireVersion is bumped whenever the shape of wireError changes in a way that
+// isn't backwards compatible, so mixed-version services can at least detect a
+// schema they don't understand instead of silently misreading it.
+const wireVersion = 1
+
+// Encode/Decode use encoding/gob rather than protobuf: this package has no
+// other external dependencies, and gob gives the same versioned,
+// cross-process wire format without requiring every consumer to vendor a
+// generated protobuf schema. The tradeoff is that gob needs every concrete
+// type that can show up in a Metadata value registered up front (see init()
+// below and RegisterMetadataType for your own types) -- an unregistered type
+// makes Encode return an error rather than silently dropping data, but it
+// will fail to encode at all. If wire compatibility with non-Go services, or
+// with `google.golang.org/protobuf`-based tooling, turns out to matter,
+// swap this file for a real protobuf schema instead.
+
+// wireError is the versioned, gob-encodable representation of a baseError
+// chain. It carries everything Encode/Decode need to reconstruct a chain on
+// the receiving side: the message, typeStr, stacktrace frames and metadata
+// for this node, plus its cause.
+type wireError struct {
+	Version    int
+	Msg        string
+	TypeStr    string
+	Stacktrace []wireFrame
+	Metadata   Metadata
+	Cause      *wireError
+	Causes     []*wireError
+}
+
+type wireFrame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+func init() {
+	// Metadata values are stored as interface{}, so gob needs every concrete
+	// type that might show up in one registered up front.
+	gob.Register("")
+	gob.Register(0)
+	gob.Register(int64(0))
+	gob.Register(float64(0))
+	gob.Register(false)
+	gob.Register(Metadata{})
+}
+
+// RegisterMetadataType registers a custom type that may appear as a Metadata
+// value, so Encode can serialize it. Call this for every concrete type you
+// pass to WithMetadata/WithMetadataDeep that isn't a string, int, int64,
+// float64, bool or Metadata -- Encode returns an error instead of panicking
+// when it hits an unregistered type, but it cannot encode it either way.
+func RegisterMetadataType(v any) {
+	gob.Register(v)
+}
+
+var (
+	typeRegistryMu sync.RWMutex
+	typeRegistry   = map[string]error{}
+)
+
+// RegisterType registers sentinel under its Type(), so Decode can hand back
+// this exact sentinel value (instead of an opaque *baseError copy) whenever
+// it decodes a leaf cause with a matching typeStr. Both sides of a network
+// boundary must call RegisterType with the same sentinels for
+// errors.Is(decoded, sentinel) to keep working after a round trip.
+func RegisterType(sentinel error) {
+	typeStr := typeOf(sentinel)
+	typeRegistryMu.Lock()
+	defer typeRegistryMu.Unlock()
+	typeRegistry[typeStr] = sentinel
+}
+
+func typeOf(err error) string {
+	var baseErr *baseError
+	if As(err, &baseErr) {
+		return baseErr.Type()
+	}
+	return reflect.TypeOf(err).String()
+}
+
+func lookupType(typeStr string) (error, bool) {
+	typeRegistryMu.RLock()
+	defer typeRegistryMu.RUnlock()
+	sentinel, ok := typeRegistry[typeStr]
+	return sentinel, ok
+}
+
+// Is reports whether target is a *baseError sharing this error's typeStr,
+// but ONLY when at least one of the two was produced by Decode. This is what
+// lets errors.Is(decoded, ErrLibraryBaseError) succeed after a chain has
+// crossed a network boundary via Encode/Decode: the decoded chain is built
+// from fresh *baseError values, so it can't be compared to the original
+// sentinel by identity, only by the typeStr both sides agree on.
+//
+// The decoded check matters: without it, any two independently-created
+// *baseErrors that happen to share a typeStr (e.g. two unrelated
+// NewWithType("...", "NotFoundError") calls) would compare equal everywhere
+// in the library, breaking the normal pointer-identity sentinel contract
+// that errors.Is/errors.As already rely on.
+func (e *baseError) Is(target error) bool {
+	t, ok := target.(*baseError)
+	if !ok || e.typeStr == "" || t.typeStr == "" {
+		return false
+	}
+	if !e.decoded && !t.decoded {
+		return false
+	}
+	return e.typeStr == t.typeStr
+}
+
+// Encode marshals err's chain (cause, message, typeStr, stacktrace frames and
+// metadata) into a versioned wire format suitable for sending across a
+// network boundary, e.g. as gRPC status details.
+func Encode(err error) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	if encErr := gob.NewEncoder(&buf).Encode(toWire(err)); encErr != nil {
+		return nil, fmt.Errorf("errors: failed to encode error: %w", encErr)
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode reconstructs an error chain from bytes produced by Encode. A typeStr
+// registered via RegisterType on this process decodes back into the
+// registered sentinel; any other typeStr decodes into an opaque *baseError
+// that still preserves typeStr, message and metadata.
+func Decode(data []byte) (error, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var w wireError
+	if decErr := gob.NewDecoder(bytes.NewReader(data)).Decode(&w); decErr != nil {
+		return nil, fmt.Errorf("errors: failed to decode error: %w", decErr)
+	}
+	return fromWire(&w), nil
+}
+
+func toWire(err error) *wireError {
+	if err == nil {
+		return nil
+	}
+	var baseErr *baseError
+	if !As(err, &baseErr) {
+		return &wireError{Version: wireVersion, Msg: err.Error()}
+	}
+	w := &wireError{
+		Version:  wireVersion,
+		Msg:      baseErr.msg,
+		TypeStr:  baseErr.typeStr,
+		Metadata: baseErr.metadata,
+	}
+	for _, f := range baseErr.stacktrace {
+		w.Stacktrace = append(w.Stacktrace, wireFrame{
+			Function: f.Function,
+			File:     f.File,
+			Line:     f.Line,
+		})
+	}
+	if baseErr.cause != nil {
+		w.Cause = toWire(baseErr.cause)
+	}
+	for _, c := range baseErr.causes {
+		w.Causes = append(w.Causes, toWire(c))
+	}
+	return w
+}
+
+func fromWire(w *wireError) error {
+	if w == nil {
+		return nil
+	}
+	if w.Cause == nil && len(w.Causes) == 0 && w.TypeStr != "" {
+		if sentinel, ok := lookupType(w.TypeStr); ok {
+			return sentinel
+		}
+	}
+	stack := make(StackTrace, 0, len(w.Stacktrace))
+	for _, f := range w.Stacktrace {
+		stack = append(stack, Frame{runtime.Frame{Function: f.Function, File: f.File, Line: f.Line}})
+	}
+	causes := make([]error, 0, len(w.Causes))
+	for _, c := range w.Causes {
+		causes = append(causes, fromWire(c))
+	}
+	return &baseError{
+		cause:      fromWire(w.Cause),
+		causes:     causes,
+		msg:        w.Msg,
+		typeStr:    w.TypeStr,
+		stacktrace: stack,
+		metadata:   w.Metadata,
+		decoded:    true,
+	}
+}
@@ -0,0 +1,58 @@
+package errors
+
+import "testing"
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	sentinel := NewSentinel("OrderNotFound", "order not found")
+	RegisterType(sentinel)
+
+	original := Wrap(SentinelWithStack(sentinel), "failed to load order").WithMetadata(Metadata{
+		"OrderID": 42,
+	})
+
+	data, err := Encode(original)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if !Is(decoded, sentinel) {
+		t.Fatalf("errors.Is(decoded, sentinel) = false, want true")
+	}
+	if got, want := decoded.Error(), original.Error(); got != want {
+		t.Fatalf("decoded.Error() = %q, want %q", got, want)
+	}
+	if id, ok := GetInt(decoded, "OrderID"); !ok || id != 42 {
+		t.Fatalf("GetInt(decoded, \"OrderID\") = (%v, %v), want (42, true)", id, ok)
+	}
+}
+
+func TestIsDoesNotMatchUnrelatedSameTypeStr(t *testing.T) {
+	a := NewWithType("user not found", "NotFoundError")
+	b := NewWithType("item not found", "NotFoundError")
+
+	if Is(a, b) {
+		t.Fatalf("Is(a, b) = true for two independently-created errors that only share a typeStr")
+	}
+}
+
+func TestIsMatchesDecodedAgainstRegisteredSentinel(t *testing.T) {
+	sentinel := NewSentinel("PaymentDeclined", "payment declined")
+	RegisterType(sentinel)
+
+	data, err := Encode(SentinelWithStack(sentinel))
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if !Is(decoded, sentinel) {
+		t.Fatalf("Is(decoded, sentinel) = false, want true")
+	}
+}
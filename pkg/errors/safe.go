@@ -0,0 +1,129 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Redacted marks a value as safe to render verbatim in SafeError output and
+// Sentry reports. Only wrap a value once you've confirmed it carries no PII.
+type Redacted struct {
+	value any
+}
+
+// Safe marks v as containing no PII, so SafeError and Report include it
+// verbatim instead of collapsing it down to its Go type name.
+func Safe(v any) any {
+	return Redacted{value: v}
+}
+
+// SafeReport is a PII-free summary of an error, suitable for sending to
+// Sentry or a log sink without leaking user data.
+type SafeReport struct {
+	Title      string
+	Message    string
+	Stacktrace StackTrace
+}
+
+// Report builds a SafeReport for err: a title formatted as typeStr@pkg.Func,
+// the redacted message chain from SafeError, and the originating stacktrace.
+// This is what the go-monitor/pkg/sentry integration should call before
+// sending an event, so no metadata value reaches Sentry unless it was
+// explicitly marked with Safe(...).
+func Report(err error) SafeReport {
+	var baseErr *baseError
+	if !As(err, &baseErr) {
+		return SafeReport{Message: safeError(err)}
+	}
+	title := baseErr.Type()
+	if len(baseErr.stacktrace) > 0 {
+		top := baseErr.stacktrace[0]
+		title = fmt.Sprintf("%s@%s.%s", baseErr.Type(), top.Package(), top.FuncName())
+	}
+	return SafeReport{
+		Title:      title,
+		Message:    baseErr.SafeError(),
+		Stacktrace: baseErr.stacktrace,
+	}
+}
+
+// SafeError renders a PII-free report of the error chain: each node's
+// typeStr/pkg.Func context (never its free-form msg, which is typically
+// built with fmt.Sprintf and interpolated data -- see New/Wrap) followed by
+// that node's metadata, with every value not wrapped in Safe(...) replaced
+// by its Go type name (e.g. "string", "int"). Unknown cause types (those not
+// produced by this package) are likewise reduced to their Go type name,
+// since their Error() text can't be assumed PII-free either.
+func (e *baseError) SafeError() string {
+	msg := e.safeContextMsg()
+	switch causes := e.Unwrap(); {
+	case len(causes) == 1:
+		msg = fmt.Sprintf("%s => %s", msg, safeError(causes[0]))
+	case len(causes) > 1:
+		lines := make([]string, len(causes))
+		for i, c := range causes {
+			lines[i] = indentTree(safeError(c))
+		}
+		msg = fmt.Sprintf("%s =>\n%s", msg, strings.Join(lines, "\n"))
+	}
+	if len(e.metadata) > 0 {
+		msg = fmt.Sprintf("%s %s", msg, safeMetadata(e.metadata))
+	}
+	return msg
+}
+
+// safeContextMsg is the PII-free part of Error()'s message: the
+// typeStr@pkg.Func context, never the free-form msg.
+func (e *baseError) safeContextMsg() string {
+	contextMsg := ""
+	if e.typeStr != "" {
+		contextMsg = e.typeStr
+	}
+	if len(e.stacktrace) > 0 {
+		top := e.stacktrace[0]
+		if contextMsg != "" {
+			contextMsg = fmt.Sprintf("%s@%s.%s", e.typeStr, top.Package(), top.FuncName())
+		} else {
+			contextMsg = fmt.Sprintf("%s.%s", top.Package(), top.FuncName())
+		}
+	}
+	return contextMsg
+}
+
+func safeError(err error) string {
+	var be *baseError
+	if As(err, &be) {
+		return be.SafeError()
+	}
+	return fmt.Sprintf("%T", err)
+}
+
+func safeMetadata(m Metadata) string {
+	parts := make([]string, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		v := m[k]
+		if nested, ok := v.(Metadata); ok {
+			parts = append(parts, fmt.Sprintf("%s=%s", k, safeMetadata(nested)))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", k, redact(v)))
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+func redact(v any) string {
+	if r, ok := v.(Redacted); ok {
+		return fmt.Sprintf("%v", r.value)
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func sortedKeys(m Metadata) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,29 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSafeErrorRedactsMessageAndMetadata(t *testing.T) {
+	err := New("failed to process order for %s", "alice@example.com").WithMetadata(Metadata{
+		"Email": "alice@example.com",
+		"Count": 3,
+		"Note":  Safe("non-pii note"),
+	})
+
+	safe := err.SafeError()
+
+	if strings.Contains(safe, "alice@example.com") {
+		t.Fatalf("SafeError leaked PII from the free-form message or an unsafe metadata value: %q", safe)
+	}
+	if !strings.Contains(safe, "Email=string") {
+		t.Fatalf("SafeError should redact the unsafe Email value to its type name, got: %q", safe)
+	}
+	if !strings.Contains(safe, "Count=int") {
+		t.Fatalf("SafeError should redact the unsafe Count value to its type name, got: %q", safe)
+	}
+	if !strings.Contains(safe, "non-pii note") {
+		t.Fatalf("SafeError should include Safe(...)-wrapped values verbatim, got: %q", safe)
+	}
+}